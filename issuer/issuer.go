@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package issuer provides a chain-agnostic, concurrency-safe way to submit
+// transactions to multiple chains and be notified once each is decided.
+package issuer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// callbackQueueSize bounds the number of in-flight confirmation
+	// callbacks. IssueTx blocks on enqueuing to this channel once it is
+	// full, which applies backpressure to callers rather than growing
+	// goroutines or memory without bound.
+	callbackQueueSize = 4096
+
+	// numCallbackWorkers is the number of goroutines draining the
+	// callback queue. Confirmation is I/O bound (polling a node), so a
+	// modest worker pool keeps many chains' confirmations progressing
+	// concurrently.
+	numCallbackWorkers = 16
+)
+
+var (
+	errUnknownChain = errors.New("issuer: unknown chain")
+	errClosed       = errors.New("issuer: closed")
+)
+
+// issuableVM is the subset of a chain's client needed to issue a transaction
+// and learn its final status. An issuableVM talks to a single node, so a
+// multi-node network is represented by registering one issuableVM per
+// (chain, node) pair.
+type issuableVM interface {
+	IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error)
+	ConfirmTx(ctx context.Context, txID ids.ID) (choices.Status, error)
+}
+
+// vmKey identifies a single registered issuableVM: a chain as seen by one
+// specific node.
+type vmKey struct {
+	chainID ids.ID
+	uri     string
+}
+
+// Issuer serializes transaction issuance per (chain, node) pair while
+// allowing issuance and confirmation across distinct chains, and across
+// distinct nodes serving the same chain, to proceed in parallel. A single
+// Issuer may be shared across many concurrent callers.
+type Issuer struct {
+	log logging.Logger
+
+	lock       sync.RWMutex
+	vms        map[vmKey]issuableVM
+	chainLocks map[vmKey]sync.Locker
+
+	// closeLock guards closed and is held across enqueueing to callbacks,
+	// so that a callback is either enqueued before Close runs or rejected
+	// outright — IssueTx can never race Close and enqueue a callback that
+	// no worker is left to drain.
+	closeLock sync.RWMutex
+	closed    bool
+
+	callbacks chan func()
+	closeOnce sync.Once
+}
+
+// New returns an uninitialized Issuer. Initialize must be called before use.
+func New() *Issuer {
+	return &Issuer{}
+}
+
+// Initialize prepares i for use, starting the worker goroutines that drain
+// confirmation callbacks. It must be called at most once.
+func (i *Issuer) Initialize(log logging.Logger) {
+	i.log = log
+	i.vms = make(map[vmKey]issuableVM)
+	i.chainLocks = make(map[vmKey]sync.Locker)
+	i.callbacks = make(chan func(), callbackQueueSize)
+
+	for w := 0; w < numCallbackWorkers; w++ {
+		go i.runCallbackWorker()
+	}
+}
+
+// RegisterChain makes chainID, as served by the node at uri, available to
+// IssueTx. Calling RegisterChain again for the same (chainID, uri) pair
+// replaces its handle. A network with multiple nodes should call
+// RegisterChain once per node for each chain that should be driven across
+// all of them.
+func (i *Issuer) RegisterChain(chainID ids.ID, uri string, vm issuableVM) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	key := vmKey{chainID: chainID, uri: uri}
+	i.vms[key] = vm
+	if _, ok := i.chainLocks[key]; !ok {
+		i.chainLocks[key] = new(sync.Mutex)
+	}
+}
+
+// IssueTx submits txBytes to chainID on the node at uri, serialized against
+// any other pending issuance on that same (chainID, uri) pair. Once the
+// transaction is decided, onDecide is invoked exactly once with its final
+// status. IssueTx itself does not block on confirmation, so callers
+// targeting different chains, or different nodes serving the same chain,
+// make progress in parallel.
+func (i *Issuer) IssueTx(ctx context.Context, chainID ids.ID, uri string, txBytes []byte, onDecide func(choices.Status)) error {
+	key := vmKey{chainID: chainID, uri: uri}
+	i.lock.RLock()
+	vm, ok := i.vms[key]
+	chainLock := i.chainLocks[key]
+	i.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s at %s", errUnknownChain, chainID, uri)
+	}
+
+	i.closeLock.RLock()
+	closed := i.closed
+	i.closeLock.RUnlock()
+	if closed {
+		return errClosed
+	}
+
+	chainLock.Lock()
+	txID, err := vm.IssueTx(ctx, txBytes)
+	chainLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	i.closeLock.RLock()
+	defer i.closeLock.RUnlock()
+	if i.closed {
+		return errClosed
+	}
+	i.callbacks <- func() { i.confirm(ctx, vm, txID, onDecide) }
+	return nil
+}
+
+// confirm always invokes onDecide exactly once, even if vm.ConfirmTx errors,
+// so that a caller blocked waiting on its callback can't be left hanging by
+// a transient confirmation failure.
+func (i *Issuer) confirm(ctx context.Context, vm issuableVM, txID ids.ID, onDecide func(choices.Status)) {
+	status, err := vm.ConfirmTx(ctx, txID)
+	if err != nil {
+		i.log.Warn("failed to confirm transaction",
+			zap.Stringer("txID", txID),
+			zap.Error(err),
+		)
+		status = choices.Unknown
+	}
+	onDecide(status)
+}
+
+// Close stops accepting new work and, once every already-enqueued callback
+// has run, stops the callback workers. Once Close returns, no further call
+// to IssueTx can enqueue a callback; any call to IssueTx racing Close is
+// either fully enqueued beforehand or rejected outright, so a callback can
+// never be silently left for a worker that has already exited.
+func (i *Issuer) Close() {
+	i.closeOnce.Do(func() {
+		i.closeLock.Lock()
+		defer i.closeLock.Unlock()
+		i.closed = true
+		close(i.callbacks)
+	})
+}
+
+func (i *Issuer) runCallbackWorker() {
+	for cb := range i.callbacks {
+		cb()
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package issuer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const testURI = "http://127.0.0.1:9650"
+
+// fakeVM is a stub issuableVM whose IssueTx and ConfirmTx behavior is
+// controlled by the test.
+type fakeVM struct {
+	// inUse is non-zero while IssueTx is executing. It is used to detect
+	// concurrent, non-serialized calls into the same (chain, uri)'s
+	// IssueTx.
+	inUse int32
+	// concurrentIssues is incremented whenever IssueTx observes inUse
+	// already set, i.e. a serialization violation.
+	concurrentIssues int32
+
+	issueDelay    time.Duration
+	confirmStatus choices.Status
+	confirmErr    error
+}
+
+func (vm *fakeVM) IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	if !atomic.CompareAndSwapInt32(&vm.inUse, 0, 1) {
+		atomic.AddInt32(&vm.concurrentIssues, 1)
+	}
+	time.Sleep(vm.issueDelay)
+	atomic.StoreInt32(&vm.inUse, 0)
+	return ids.GenerateTestID(), nil
+}
+
+func (vm *fakeVM) ConfirmTx(ctx context.Context, txID ids.ID) (choices.Status, error) {
+	return vm.confirmStatus, vm.confirmErr
+}
+
+func newTestIssuer(t *testing.T) *Issuer {
+	i := New()
+	i.Initialize(logging.NoLog{})
+	t.Cleanup(i.Close)
+	return i
+}
+
+func TestIssuerSerializesIssuancePerChainAndURI(t *testing.T) {
+	require := require.New(t)
+
+	i := newTestIssuer(t)
+	chainID := ids.GenerateTestID()
+	vm := &fakeVM{issueDelay: 20 * time.Millisecond, confirmStatus: choices.Accepted}
+	i.RegisterChain(chainID, testURI, vm)
+
+	const numIssuers = 8
+	var wg sync.WaitGroup
+	wg.Add(numIssuers)
+	for n := 0; n < numIssuers; n++ {
+		go func() {
+			defer wg.Done()
+			require.NoError(i.IssueTx(context.Background(), chainID, testURI, nil, func(choices.Status) {}))
+		}()
+	}
+	wg.Wait()
+
+	require.Zero(atomic.LoadInt32(&vm.concurrentIssues), "IssueTx was entered concurrently for the same (chain, uri)")
+}
+
+func TestIssuerIssuesAcrossChainsInParallel(t *testing.T) {
+	require := require.New(t)
+
+	i := newTestIssuer(t)
+
+	const (
+		numChains  = 4
+		issueDelay = 50 * time.Millisecond
+	)
+	chainIDs := make([]ids.ID, numChains)
+	for n := range chainIDs {
+		chainIDs[n] = ids.GenerateTestID()
+		i.RegisterChain(chainIDs[n], testURI, &fakeVM{issueDelay: issueDelay, confirmStatus: choices.Accepted})
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	wg.Add(numChains)
+	for _, chainID := range chainIDs {
+		chainID := chainID
+		go func() {
+			defer wg.Done()
+			require.NoError(i.IssueTx(context.Background(), chainID, testURI, nil, func(choices.Status) {}))
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	require.Less(elapsed, numChains*issueDelay, "issuance across distinct chains should overlap, not serialize")
+}
+
+func TestIssuerIssuesAcrossURIsInParallel(t *testing.T) {
+	require := require.New(t)
+
+	i := newTestIssuer(t)
+	chainID := ids.GenerateTestID()
+
+	const (
+		numURIs    = 4
+		issueDelay = 50 * time.Millisecond
+	)
+	uris := make([]string, numURIs)
+	for n := range uris {
+		uris[n] = testURI + string(rune('a'+n))
+		i.RegisterChain(chainID, uris[n], &fakeVM{issueDelay: issueDelay, confirmStatus: choices.Accepted})
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	wg.Add(numURIs)
+	for _, uri := range uris {
+		uri := uri
+		go func() {
+			defer wg.Done()
+			require.NoError(i.IssueTx(context.Background(), chainID, uri, nil, func(choices.Status) {}))
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	require.Less(elapsed, numURIs*issueDelay, "issuance across distinct nodes serving the same chain should overlap, not serialize")
+}
+
+func TestIssuerConfirmInvokesOnDecideExactlyOnce(t *testing.T) {
+	tests := []struct {
+		name          string
+		confirmStatus choices.Status
+		confirmErr    error
+		wantStatus    choices.Status
+	}{
+		{
+			name:          "accepted",
+			confirmStatus: choices.Accepted,
+			wantStatus:    choices.Accepted,
+		},
+		{
+			name:          "rejected",
+			confirmStatus: choices.Rejected,
+			wantStatus:    choices.Rejected,
+		},
+		{
+			name:       "confirm error still decides, as unknown",
+			confirmErr: errors.New("transient node error"),
+			wantStatus: choices.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			i := newTestIssuer(t)
+			chainID := ids.GenerateTestID()
+			i.RegisterChain(chainID, testURI, &fakeVM{confirmStatus: tt.confirmStatus, confirmErr: tt.confirmErr})
+
+			var calls int32
+			decided := make(chan choices.Status, 1)
+			onDecide := func(s choices.Status) {
+				atomic.AddInt32(&calls, 1)
+				decided <- s
+			}
+
+			require.NoError(i.IssueTx(context.Background(), chainID, testURI, nil, onDecide))
+
+			select {
+			case s := <-decided:
+				require.Equal(tt.wantStatus, s)
+			case <-time.After(5 * time.Second):
+				t.Fatal("onDecide was never invoked")
+			}
+
+			// Give a stray second invocation a chance to land before asserting.
+			time.Sleep(10 * time.Millisecond)
+			require.EqualValues(1, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestIssuerIssueTxUnknownChain(t *testing.T) {
+	require := require.New(t)
+
+	i := newTestIssuer(t)
+	err := i.IssueTx(context.Background(), ids.GenerateTestID(), testURI, nil, func(choices.Status) {
+		t.Fatal("onDecide should not be invoked when the chain is unknown")
+	})
+	require.ErrorIs(err, errUnknownChain)
+}
+
+func TestIssuerIssueTxAfterCloseFails(t *testing.T) {
+	require := require.New(t)
+
+	i := New()
+	i.Initialize(logging.NoLog{})
+	chainID := ids.GenerateTestID()
+	i.RegisterChain(chainID, testURI, &fakeVM{confirmStatus: choices.Accepted})
+
+	i.Close()
+
+	err := i.IssueTx(context.Background(), chainID, testURI, nil, func(choices.Status) {
+		t.Fatal("onDecide should not be invoked once the Issuer is closed")
+	})
+	require.ErrorIs(err, errClosed)
+}
+
+func TestIssuerCloseRunsCallbacksEnqueuedBeforeIt(t *testing.T) {
+	require := require.New(t)
+
+	i := New()
+	i.Initialize(logging.NoLog{})
+	chainID := ids.GenerateTestID()
+	i.RegisterChain(chainID, testURI, &fakeVM{confirmStatus: choices.Accepted})
+
+	decided := make(chan choices.Status, 1)
+	require.NoError(i.IssueTx(context.Background(), chainID, testURI, nil, func(s choices.Status) {
+		decided <- s
+	}))
+	i.Close()
+
+	select {
+	case s := <-decided:
+		require.Equal(choices.Accepted, s)
+	case <-time.After(5 * time.Second):
+		t.Fatal("a callback enqueued before Close was never run")
+	}
+}
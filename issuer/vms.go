@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package issuer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+
+	"github.com/ava-labs/coreth/plugin/evm"
+)
+
+// confirmPollFreq is how often a confirmTx adapter polls a node for a
+// transaction's final status.
+const confirmPollFreq = 100 * time.Millisecond
+
+// NewXChainVM returns an issuableVM backed by the AVM at uri.
+func NewXChainVM(uri string) issuableVM {
+	return xChainVM{client: avm.NewClient(uri, "X")}
+}
+
+type xChainVM struct {
+	client avm.Client
+}
+
+func (vm xChainVM) IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	return vm.client.IssueTx(ctx, txBytes)
+}
+
+func (vm xChainVM) ConfirmTx(ctx context.Context, txID ids.ID) (choices.Status, error) {
+	return vm.client.ConfirmTx(ctx, txID, confirmPollFreq)
+}
+
+// NewPChainVM returns an issuableVM backed by the P-chain at uri.
+func NewPChainVM(uri string) issuableVM {
+	return pChainVM{client: platformvm.NewClient(uri)}
+}
+
+type pChainVM struct {
+	client platformvm.Client
+}
+
+func (vm pChainVM) IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	return vm.client.IssueTx(ctx, txBytes)
+}
+
+func (vm pChainVM) ConfirmTx(ctx context.Context, txID ids.ID) (choices.Status, error) {
+	res, err := vm.client.AwaitTxDecided(ctx, txID, confirmPollFreq)
+	if err != nil {
+		return choices.Unknown, err
+	}
+	return platformStatusToChoicesStatus(res.Status), nil
+}
+
+func platformStatusToChoicesStatus(s status.Status) choices.Status {
+	switch s {
+	case status.Committed:
+		return choices.Accepted
+	case status.Aborted, status.Dropped:
+		return choices.Rejected
+	default:
+		return choices.Processing
+	}
+}
+
+// NewCChainVM returns an issuableVM backed by the C-chain at uri.
+func NewCChainVM(uri string) issuableVM {
+	return cChainVM{client: evm.NewClient(uri, "C")}
+}
+
+type cChainVM struct {
+	client evm.Client
+}
+
+func (vm cChainVM) IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	return vm.client.IssueTx(ctx, txBytes)
+}
+
+func (vm cChainVM) ConfirmTx(ctx context.Context, txID ids.ID) (choices.Status, error) {
+	ticker := time.NewTicker(confirmPollFreq)
+	defer ticker.Stop()
+
+	for {
+		res, err := vm.client.GetAtomicTxStatus(ctx, txID)
+		if err != nil {
+			return choices.Unknown, err
+		}
+		if res.Status == evm.Accepted || res.Status == evm.Dropped {
+			return cChainStatusToChoicesStatus(res.Status), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return choices.Unknown, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func cChainStatusToChoicesStatus(s evm.Status) choices.Status {
+	if s == evm.Accepted {
+		return choices.Accepted
+	}
+	return choices.Rejected
+}
@@ -40,3 +40,20 @@ func NewCreator(metrics prometheus.Registerer, compressionEnabled bool, parentNa
 	}
 	return res, nil
 }
+
+// NewCreatorWithKeystore is identical to NewCreator, but additionally
+// validates the keystore-backed identity the node will use to sign messages
+// against keystoreConfig.PasswordPolicy (a zxcvbn policy requiring a score of
+// at least [defaultMinPasswordScore] if PasswordPolicy is unset). It returns
+// ErrUserPassMaxLength or ErrWeakPassword rather than constructing a Creator
+// for a rejected identity.
+func NewCreatorWithKeystore(metrics prometheus.Registerer, compressionEnabled bool, parentNamespace string, keystoreConfig KeystoreConfig) (Creator, error) {
+	policy := keystoreConfig.PasswordPolicy
+	if policy == nil {
+		policy = NewZxcvbnPasswordPolicy(defaultMinPasswordScore)
+	}
+	if err := policy.Validate(keystoreConfig.Username, keystoreConfig.Password); err != nil {
+		return nil, err
+	}
+	return NewCreator(metrics, compressionEnabled, parentNamespace)
+}
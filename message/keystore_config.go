@@ -0,0 +1,67 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/trustelem/zxcvbn"
+)
+
+// maxUserPassLen is the maximum number of characters allowed in a keystore
+// username or password.
+const maxUserPassLen = 1024
+
+// defaultMinPasswordScore is the minimum acceptable zxcvbn strength score
+// ([0,4]) for a keystore password when no PasswordPolicy is supplied.
+const defaultMinPasswordScore = 2
+
+var (
+	ErrUserPassMaxLength = errors.New("username and password must not exceed 1024 characters")
+	ErrWeakPassword      = errors.New("password is too weak")
+
+	_ PasswordPolicy = (*zxcvbnPasswordPolicy)(nil)
+)
+
+// PasswordPolicy decides whether a keystore username/password pair is
+// acceptable. Operators may supply their own implementation to raise (or
+// otherwise customize) the bar enforced by the default policy.
+type PasswordPolicy interface {
+	Validate(username, password string) error
+}
+
+// KeystoreConfig configures the keystore-backed identity a node uses to sign
+// messages. PasswordPolicy defaults to a zxcvbn policy requiring a score of
+// at least [defaultMinPasswordScore] when left unset.
+type KeystoreConfig struct {
+	Username       string
+	Password       string
+	PasswordPolicy PasswordPolicy
+}
+
+type zxcvbnPasswordPolicy struct {
+	minScore int
+}
+
+// NewZxcvbnPasswordPolicy returns a PasswordPolicy that rejects
+// usernames/passwords longer than [maxUserPassLen] characters and passwords
+// whose zxcvbn strength score is below minScore.
+func NewZxcvbnPasswordPolicy(minScore int) PasswordPolicy {
+	return &zxcvbnPasswordPolicy{
+		minScore: minScore,
+	}
+}
+
+func (p *zxcvbnPasswordPolicy) Validate(username, password string) error {
+	if utf8.RuneCountInString(username) > maxUserPassLen || utf8.RuneCountInString(password) > maxUserPassLen {
+		return ErrUserPassMaxLength
+	}
+
+	strength := zxcvbn.PasswordStrength(password, []string{username})
+	if strength.Score < p.minScore {
+		return ErrWeakPassword
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZxcvbnPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		minScore int
+		wantErr  error
+	}{
+		{
+			name:     "username at max length is allowed",
+			username: strings.Repeat("a", maxUserPassLen),
+			password: "anything",
+			minScore: 0,
+		},
+		{
+			name:     "username over max length is rejected",
+			username: strings.Repeat("a", maxUserPassLen+1),
+			password: "anything",
+			minScore: 0,
+			wantErr:  ErrUserPassMaxLength,
+		},
+		{
+			name:     "password at max length is allowed",
+			username: "user",
+			password: strings.Repeat("a", maxUserPassLen),
+			minScore: 0,
+		},
+		{
+			name:     "password over max length is rejected",
+			username: "user",
+			password: strings.Repeat("a", maxUserPassLen+1),
+			minScore: 0,
+			wantErr:  ErrUserPassMaxLength,
+		},
+		{
+			// zxcvbn scores are in [0,4], so requiring a score of 0 can
+			// never be failed by the score check.
+			name:     "a minScore of 0 is always satisfied",
+			username: "user",
+			password: "whatever123",
+			minScore: 0,
+		},
+		{
+			// zxcvbn scores are in [0,4], so requiring a score above 4 can
+			// never be satisfied by the score check.
+			name:     "a minScore above the maximum possible score is never satisfied",
+			username: "user",
+			password: "correct horse battery staple 42!",
+			minScore: 5,
+			wantErr:  ErrWeakPassword,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			policy := NewZxcvbnPasswordPolicy(tt.minScore)
+			err := policy.Validate(tt.username, tt.password)
+			if tt.wantErr == nil {
+				require.NoError(err)
+				return
+			}
+			require.ErrorIs(err, tt.wantErr)
+		})
+	}
+}
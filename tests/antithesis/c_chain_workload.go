@@ -0,0 +1,331 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/plugin/evm"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+// simpleStorageBytecode deploys a minimal contract that stores its
+// constructor argument and exposes it via the implicit public getter. It is
+// only used to exercise contract creation/call on the C-chain; its exact
+// behavior is not asserted.
+const simpleStorageBytecode = "608060405234801561001057600080fd5b506040516101" +
+	"c43803806101c48339818101604052810190610032919061007a565b806000819055505" +
+	"06100a7565b600080fd5b6000819050919050565b61005781610044565b811461006257" +
+	"600080fd5b50565b6000815190506100748161004e565b92915050565b600060208284" +
+	"0312156100905761008f61003f565b5b600061009e84828501610065565b9150509291" +
+	"5050565b60ff806100b56000396000f3fe6080604052600080fdfea2646970667358221" +
+	"220000000000000000000000000000000000000000000000000000000000000000064" +
+	"736f6c63430008120033"
+
+func (w *workload) issueXToCTransfer(ctx context.Context) {
+	var (
+		xWallet  = w.wallet.X()
+		cWallet  = w.wallet.C()
+		xBuilder = xWallet.Builder()
+	)
+	balances, err := xBuilder.GetFTBalance()
+	if err != nil {
+		log.Printf("failed to fetch X-chain balances: %s", err)
+		return
+	}
+
+	var (
+		avaxAssetID   = xWallet.AVAXAssetID()
+		avaxBalance   = balances[avaxAssetID]
+		xBaseTxFee    = xWallet.BaseTxFee()
+		neededBalance = xBaseTxFee + units.Avax
+	)
+	if avaxBalance < neededBalance {
+		log.Printf("skipping X-chain tx issuance due to insufficient balance: %d < %d", avaxBalance, neededBalance)
+		return
+	}
+
+	exportStartTime := time.Now()
+	exportTx, err := xWallet.IssueExportTx(
+		w.wallet.C().BlockchainID(),
+		[]*avax.TransferableOutput{{
+			Asset: avax.Asset{
+				ID: avaxAssetID,
+			},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs: []ids.ShortID{
+						w.key.Address(),
+					},
+				},
+			},
+		}},
+	)
+	if err != nil {
+		log.Printf("failed to issue X-chain export transaction: %s", err)
+		return
+	}
+	log.Printf("created X-chain export transaction %s in %s", exportTx.ID(), time.Since(exportStartTime))
+
+	var (
+		xChainID        = xWallet.BlockchainID()
+		ethAddr         = evm.PublicKeyToEthAddress(w.key.PublicKey())
+		importStartTime = time.Now()
+	)
+	importTx, err := cWallet.IssueImportTx(xChainID, ethAddr)
+	if err != nil {
+		log.Printf("failed to issue C-chain import transaction: %s", err)
+		return
+	}
+	log.Printf("created C-chain import transaction %s in %s", importTx.ID(), time.Since(importStartTime))
+
+	w.confirmXChainTx(ctx, exportTx)
+	w.verifyXChainTxConsumedUTXOs(ctx, exportTx)
+	w.confirmCChainTx(ctx, importTx)
+	w.verifyCChainTxConsumedUTXOs(ctx, importTx)
+}
+
+func (w *workload) issueCToPTransfer(ctx context.Context) {
+	var (
+		cWallet = w.wallet.C()
+		pWallet = w.wallet.P()
+	)
+	balances, err := cWallet.Builder().GetFTBalance()
+	if err != nil {
+		log.Printf("failed to fetch C-chain balances: %s", err)
+		return
+	}
+
+	var (
+		avaxAssetID   = cWallet.AVAXAssetID()
+		avaxBalance   = balances[avaxAssetID]
+		pBaseTxFee    = pWallet.BaseTxFee()
+		neededBalance = pBaseTxFee + units.Schmeckle
+	)
+	if avaxBalance < neededBalance {
+		log.Printf("skipping C-chain tx issuance due to insufficient balance: %d < %d", avaxBalance, neededBalance)
+		return
+	}
+
+	var (
+		owner           = w.makeOwner()
+		exportStartTime = time.Now()
+	)
+	exportTx, err := cWallet.IssueExportTx(
+		constants.PlatformChainID,
+		[]*secp256k1fx.TransferOutput{{
+			Amt:          units.Schmeckle,
+			OutputOwners: owner,
+		}},
+	)
+	if err != nil {
+		log.Printf("failed to issue C-chain export transaction: %s", err)
+		return
+	}
+	log.Printf("created C-chain export transaction %s in %s", exportTx.ID(), time.Since(exportStartTime))
+
+	var (
+		cChainID        = cWallet.BlockchainID()
+		importStartTime = time.Now()
+	)
+	importTx, err := pWallet.IssueImportTx(cChainID, &owner)
+	if err != nil {
+		log.Printf("failed to issue P-chain import transaction: %s", err)
+		return
+	}
+	log.Printf("created P-chain import transaction %s in %s", importTx.ID(), time.Since(importStartTime))
+
+	w.confirmCChainTx(ctx, exportTx)
+	w.verifyCChainTxConsumedUTXOs(ctx, exportTx)
+	w.confirmPChainTx(ctx, importTx)
+	w.verifyPChainTxConsumedUTXOs(ctx, importTx)
+}
+
+// issueCChainNativeTransfer exercises the EVM directly: a native AVAX value
+// transfer from this workload's C-chain address back to itself, followed by a
+// simple contract deployment and a call into it.
+func (w *workload) issueCChainNativeTransfer(ctx context.Context) {
+	uri := w.uris[0]
+	ethClient, err := ethclient.Dial(uri + "/ext/bc/C/rpc")
+	if err != nil {
+		log.Printf("failed to dial C-chain RPC at %s: %s", uri, err)
+		return
+	}
+	defer ethClient.Close()
+
+	ecdsaKey := w.key.ToECDSA()
+	fromAddr := evm.PublicKeyToEthAddress(w.key.PublicKey())
+
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		log.Printf("failed to fetch C-chain ID: %s", err)
+		return
+	}
+	nonce, err := ethClient.NonceAt(ctx, fromAddr, nil)
+	if err != nil {
+		log.Printf("failed to fetch C-chain nonce for %s: %s", fromAddr, err)
+		return
+	}
+	gasPrice, err := ethClient.SuggestGasPrice(ctx)
+	if err != nil {
+		log.Printf("failed to fetch C-chain gas price: %s", err)
+		return
+	}
+	signer := types.LatestSignerForChainID(chainID)
+
+	transferStartTime := time.Now()
+	transferTx, err := types.SignNewTx(ecdsaKey, signer, &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &fromAddr,
+		Value:    big.NewInt(int64(units.MilliAvax)),
+		Gas:      21_000,
+		GasPrice: gasPrice,
+	})
+	if err != nil {
+		log.Printf("failed to sign C-chain value transfer: %s", err)
+		return
+	}
+	if err := ethClient.SendTransaction(ctx, transferTx); err != nil {
+		log.Printf("failed to send C-chain value transfer: %s", err)
+		return
+	}
+	if err := w.awaitEthTxMined(ctx, ethClient, transferTx.Hash()); err != nil {
+		log.Printf("failed to confirm C-chain value transfer %s: %s", transferTx.Hash(), err)
+		return
+	}
+	log.Printf("confirmed C-chain value transfer %s in %s", transferTx.Hash(), time.Since(transferStartTime))
+
+	deployStartTime := time.Now()
+	deployTx, err := types.SignNewTx(ecdsaKey, signer, &types.LegacyTx{
+		Nonce:    nonce + 1,
+		Gas:      200_000,
+		GasPrice: gasPrice,
+		Data:     ethcommon.FromHex(simpleStorageBytecode),
+	})
+	if err != nil {
+		log.Printf("failed to sign C-chain contract deployment: %s", err)
+		return
+	}
+	if err := ethClient.SendTransaction(ctx, deployTx); err != nil {
+		log.Printf("failed to send C-chain contract deployment: %s", err)
+		return
+	}
+	if err := w.awaitEthTxMined(ctx, ethClient, deployTx.Hash()); err != nil {
+		log.Printf("failed to confirm C-chain contract deployment %s: %s", deployTx.Hash(), err)
+		return
+	}
+	receipt, err := ethClient.TransactionReceipt(ctx, deployTx.Hash())
+	if err != nil {
+		log.Printf("failed to fetch C-chain contract deployment receipt %s: %s", deployTx.Hash(), err)
+		return
+	}
+	log.Printf("deployed C-chain contract %s in %s", receipt.ContractAddress, time.Since(deployStartTime))
+
+	callStartTime := time.Now()
+	callTx, err := types.SignNewTx(ecdsaKey, signer, &types.LegacyTx{
+		Nonce:    nonce + 2,
+		To:       &receipt.ContractAddress,
+		Gas:      100_000,
+		GasPrice: gasPrice,
+	})
+	if err != nil {
+		log.Printf("failed to sign C-chain contract call: %s", err)
+		return
+	}
+	if err := ethClient.SendTransaction(ctx, callTx); err != nil {
+		log.Printf("failed to send C-chain contract call: %s", err)
+		return
+	}
+	if err := w.awaitEthTxMined(ctx, ethClient, callTx.Hash()); err != nil {
+		log.Printf("failed to confirm C-chain contract call %s: %s", callTx.Hash(), err)
+		return
+	}
+	log.Printf("confirmed C-chain contract call %s in %s", callTx.Hash(), time.Since(callStartTime))
+}
+
+func (w *workload) awaitEthTxMined(ctx context.Context, ethClient ethclient.Client, txHash ethcommon.Hash) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		_, isPending, err := ethClient.TransactionByHash(ctx, txHash)
+		if err == nil && !isPending {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *workload) confirmCChainTx(ctx context.Context, tx *evm.Tx) {
+	txID := tx.ID()
+	decisions, err := w.awaitDecision(ctx, w.wallet.C().BlockchainID(), tx.Bytes())
+	if err != nil {
+		log.Printf("failed to confirm C-chain transaction %s via issuer: %s", txID, err)
+		return
+	}
+	for _, d := range decisions {
+		if d.status != choices.Accepted {
+			log.Printf("failed to confirm C-chain transaction %s on %s: status == %s", txID, d.uri, d.status)
+			return
+		}
+	}
+	log.Printf("confirmed C-chain transaction %s on all nodes via issuer", txID)
+}
+
+func (w *workload) verifyCChainTxConsumedUTXOs(ctx context.Context, tx *evm.Tx) {
+	txID := tx.ID()
+	chainID := w.wallet.C().BlockchainID()
+	for _, uri := range w.uris {
+		client := evm.NewClient(uri, "C")
+
+		utxos := common.NewUTXOs()
+		err := primary.AddAllUTXOs(
+			ctx,
+			utxos,
+			client,
+			evm.Codec,
+			chainID,
+			chainID,
+			w.addrs.List(),
+		)
+		if err != nil {
+			log.Printf("failed to fetch C-chain UTXOs on %s: %s", uri, err)
+			return
+		}
+
+		inputs := tx.Unsigned.InputUTXOs()
+		for _, input := range inputs {
+			_, err := utxos.GetUTXO(ctx, chainID, chainID, input.InputID())
+			if err != database.ErrNotFound {
+				log.Printf("failed to verify that C-chain UTXO %s was deleted on %s after %s", input.InputID(), uri, txID)
+				return
+			}
+		}
+		log.Printf("confirmed all C-chain UTXOs consumed by %s are not present on %s", txID, uri)
+	}
+	log.Printf("confirmed all C-chain UTXOs consumed by %s are not present on all nodes", txID)
+}
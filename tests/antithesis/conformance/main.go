@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Command conformance replays a corpus of pre-serialized transaction vectors
+// against a running network and checks that each produces the expected
+// status, consumed UTXOs and post-state balances. Unlike the antithesis
+// workload's random fuzzing, this is a deterministic compliance suite
+// intended to be wired into CI.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/issuer"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+// vectorTimeout bounds how long a single vector may take to issue, confirm
+// and verify. Confirmation polls the node until the transaction is decided,
+// so without it a transaction that is never decided would hang the whole CI
+// run; with it, such a vector fails on its own.
+const vectorTimeout = 30 * time.Second
+
+func main() {
+	var (
+		vectorsDir  = flag.String("vectors-dir", "", "directory of JSON conformance vectors to replay")
+		uris        = flag.String("uris", "", "comma-separated node URIs; vectors are replayed against the first one only")
+		xChainIDStr = flag.String("x-chain-id", "", "blockchain ID of the X-chain, required if any vector targets X")
+		reportPath  = flag.String("report", "conformance-report.xml", "path to write the JUnit report to")
+	)
+	flag.Parse()
+
+	if os.Getenv("SKIP_CONFORMANCE") == "1" {
+		log.Println("SKIP_CONFORMANCE=1 set, skipping conformance suite")
+		return
+	}
+	if *vectorsDir == "" {
+		log.Fatal("--vectors-dir is required")
+	}
+	if *uris == "" {
+		log.Fatal("--uris is required")
+	}
+	uri := strings.Split(*uris, ",")[0]
+
+	var xChainID ids.ID
+	if *xChainIDStr != "" {
+		var err error
+		xChainID, err = ids.FromString(*xChainIDStr)
+		if err != nil {
+			log.Fatalf("invalid --x-chain-id %q: %s", *xChainIDStr, err)
+		}
+	}
+
+	vectors, err := loadVectors(*vectorsDir)
+	if err != nil {
+		log.Fatalf("failed to load vectors: %s", err)
+	}
+	log.Printf("loaded %d conformance vectors from %s", len(vectors), *vectorsDir)
+
+	issr := issuer.New()
+	issr.Initialize(logging.NoLog{})
+	issr.RegisterChain(constants.PlatformChainID, uri, issuer.NewPChainVM(uri))
+	if xChainID != ids.Empty {
+		issr.RegisterChain(xChainID, uri, issuer.NewXChainVM(uri))
+	}
+
+	ctx := context.Background()
+	results := make([]vectorResult, 0, len(vectors))
+	failed := 0
+	for _, v := range vectors {
+		start := time.Now()
+		vectorCtx, cancel := context.WithTimeout(ctx, vectorTimeout)
+		err := replay(vectorCtx, issr, uri, xChainID, v)
+		cancel()
+		results = append(results, vectorResult{
+			name:     v.Name,
+			duration: time.Since(start),
+			err:      err,
+		})
+		if err != nil {
+			failed++
+			log.Printf("FAIL %s: %s", v.Name, err)
+			continue
+		}
+		log.Printf("PASS %s", v.Name)
+	}
+
+	if err := writeJUnitReport(*reportPath, results); err != nil {
+		log.Fatalf("failed to write JUnit report to %s: %s", *reportPath, err)
+	}
+	log.Printf("wrote JUnit report to %s", *reportPath)
+
+	if failed > 0 {
+		log.Fatalf("%d/%d conformance vectors failed", failed, len(vectors))
+	}
+}
+
+// replay issues v.TxBytes on its chain, confirms it reaches
+// v.ExpectedStatus, and checks that every UTXO in
+// v.ExpectedConsumedUTXOs was consumed and every balance in
+// v.ExpectedBalances matches.
+func replay(ctx context.Context, issr *issuer.Issuer, uri string, xChainID ids.ID, v vector) error {
+	chainID, err := chainIDFor(v.Chain, xChainID)
+	if err != nil {
+		return err
+	}
+
+	decided := make(chan choices.Status, 1)
+	if err := issr.IssueTx(ctx, chainID, uri, v.TxBytes, func(s choices.Status) { decided <- s }); err != nil {
+		return fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	var status choices.Status
+	select {
+	case status = <-decided:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if status.String() != v.ExpectedStatus {
+		return fmt.Errorf("expected status %q, got %q", v.ExpectedStatus, status)
+	}
+
+	if err := verifyConsumedUTXOs(ctx, v.Chain, uri, chainID, v.ExpectedConsumedUTXOs, v.Addresses); err != nil {
+		return err
+	}
+	return verifyBalances(ctx, v.Chain, uri, v.ExpectedBalances)
+}
+
+func chainIDFor(chain string, xChainID ids.ID) (ids.ID, error) {
+	switch chain {
+	case "X":
+		if xChainID == ids.Empty {
+			return ids.Empty, errors.New("vector targets the X-chain but --x-chain-id was not set")
+		}
+		return xChainID, nil
+	case "P":
+		return constants.PlatformChainID, nil
+	default:
+		return ids.Empty, fmt.Errorf("unsupported chain %q", chain)
+	}
+}
+
+func verifyConsumedUTXOs(ctx context.Context, chain, uri string, chainID ids.ID, expected []ids.ID, addrs []string) error {
+	if len(expected) == 0 {
+		return nil
+	}
+	if len(addrs) == 0 {
+		return errors.New("vector has expectedConsumedUTXOs but no addresses to look them up under")
+	}
+
+	shortAddrs := make([]ids.ShortID, len(addrs))
+	for i, addr := range addrs {
+		_, _, addrBytes, err := address.Parse(addr)
+		if err != nil {
+			return fmt.Errorf("failed to parse address %q: %w", addr, err)
+		}
+		shortAddrs[i], err = ids.ToShortID(addrBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse address %q: %w", addr, err)
+		}
+	}
+
+	utxos := common.NewUTXOs()
+	var err error
+	switch chain {
+	case "X":
+		err = primary.AddAllUTXOs(ctx, utxos, avm.NewClient(uri, "X"), x.Parser.Codec(), chainID, chainID, shortAddrs)
+	case "P":
+		err = primary.AddAllUTXOs(ctx, utxos, platformvm.NewClient(uri), txs.Codec, chainID, chainID, shortAddrs)
+	default:
+		return fmt.Errorf("unsupported chain %q", chain)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s-chain UTXOs: %w", chain, err)
+	}
+
+	for _, utxoID := range expected {
+		if _, err := utxos.GetUTXO(ctx, chainID, chainID, utxoID); !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("expected UTXO %s to be consumed, but it was not", utxoID)
+		}
+	}
+	return nil
+}
+
+func verifyBalances(ctx context.Context, chain, uri string, expected map[string]uint64) error {
+	for addr, want := range expected {
+		var got uint64
+		var err error
+		switch chain {
+		case "X":
+			got, err = avm.NewClient(uri, "X").GetBalance(ctx, addr, "AVAX", false)
+		case "P":
+			var reply *platformvm.GetBalanceReply
+			reply, err = platformvm.NewClient(uri).GetBalance(ctx, []string{addr})
+			if err == nil {
+				got = uint64(reply.Balance)
+			}
+		default:
+			return fmt.Errorf("unsupported chain %q", chain)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch balance for %s: %w", addr, err)
+		}
+		if got != want {
+			return fmt.Errorf("expected balance %d for %s, got %d", want, addr, got)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/xml"
+	"os"
+	"time"
+)
+
+// junitSuite is a minimal encoding/xml model of a JUnit test suite, covering
+// just enough of the schema for CI systems to render pass/fail per vector.
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     float64     `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// vectorResult is the outcome of replaying a single vector.
+type vectorResult struct {
+	name     string
+	duration time.Duration
+	err      error
+}
+
+func writeJUnitReport(path string, results []vectorResult) error {
+	suite := junitSuite{
+		Name:  "antithesis-conformance",
+		Tests: len(results),
+	}
+	for _, r := range results {
+		c := junitCase{
+			Name: r.name,
+			Time: r.duration.Seconds(),
+		}
+		if r.err != nil {
+			suite.Failures++
+			c.Failure = &junitFailure{
+				Message: r.err.Error(),
+				Body:    r.err.Error(),
+			}
+		}
+		suite.Time += c.Time
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	b, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append([]byte(xml.Header), b...)
+	return os.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// vector is a single, pre-serialized conformance test case: a raw
+// transaction plus the outcome a conforming implementation must produce.
+type vector struct {
+	// Name identifies the vector in log output and the JUnit report.
+	Name string `json:"name"`
+	// Chain is either "X" or "P".
+	Chain string `json:"chain"`
+	// TxBytes is the raw, already-signed transaction to submit.
+	TxBytes []byte `json:"txBytes"`
+	// ExpectedStatus is the tx status the chain must reach, e.g.
+	// "Accepted" or "Rejected".
+	ExpectedStatus string `json:"expectedStatus"`
+	// ExpectedConsumedUTXOs lists UTXO IDs that must no longer exist once
+	// the transaction is decided.
+	ExpectedConsumedUTXOs []ids.ID `json:"expectedConsumedUTXOs"`
+	// ExpectedBalances maps a bech32 address to its expected AVAX balance
+	// once the transaction is decided.
+	ExpectedBalances map[string]uint64 `json:"expectedBalances"`
+	// Addresses lists the bech32 addresses that own ExpectedConsumedUTXOs,
+	// so the UTXO cache used to verify they were consumed can be populated.
+	Addresses []string `json:"addresses"`
+}
+
+// loadVectors reads every *.json file in dir as a vector, sorted by
+// filename so replay order is deterministic.
+func loadVectors(dir string) ([]vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vectors dir %q: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	vectors := make([]vector, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vector %q: %w", path, err)
+		}
+
+		var v vector
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse vector %q: %w", path, err)
+		}
+		if v.Name == "" {
+			v.Name = name
+		}
+		vectors = append(vectors, v)
+	}
+	return vectors, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	xtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+)
+
+// dependentUTXOChainLength is the number of BaseTxes issued back-to-back by
+// issueXChainDependentChain, with no confirmation in between. Each one spends
+// the prior tx's still-pending change output.
+const dependentUTXOChainLength = 5
+
+// issueXChainDependentChain issues a chain of X-chain BaseTxes where each
+// one spends the still-pending change output of the tx before it, without
+// waiting for any of them to be confirmed in between. This exercises the
+// AVM's handling of unconfirmed UTXOs and the credential path for dependent
+// transactions, which has historically been a source of bugs.
+func (w *workload) issueXChainDependentChain(ctx context.Context) {
+	var (
+		xWallet  = w.wallet.X()
+		xBuilder = xWallet.Builder()
+	)
+	balances, err := xBuilder.GetFTBalance()
+	if err != nil {
+		log.Printf("failed to fetch X-chain balances: %s", err)
+		return
+	}
+
+	var (
+		avaxAssetID   = xWallet.AVAXAssetID()
+		avaxBalance   = balances[avaxAssetID]
+		baseTxFee     = xWallet.BaseTxFee()
+		neededBalance = dependentUTXOChainLength * (baseTxFee + units.Schmeckle)
+	)
+	if avaxBalance < neededBalance {
+		log.Printf("skipping X-chain dependent UTXO chain due to insufficient balance: %d < %d", avaxBalance, neededBalance)
+		return
+	}
+
+	var (
+		owner          = w.makeOwner()
+		chainTxs       = make([]*xtxs.Tx, 0, dependentUTXOChainLength)
+		chainStartTime = time.Now()
+	)
+	for i := 0; i < dependentUTXOChainLength; i++ {
+		tx, err := xWallet.IssueBaseTx(
+			[]*avax.TransferableOutput{
+				{
+					Asset: avax.Asset{
+						ID: avaxAssetID,
+					},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          units.Schmeckle,
+						OutputOwners: owner,
+					},
+				},
+			},
+			common.WithAssumeDecided(),
+		)
+		if err != nil {
+			log.Printf("failed to issue dependent X-chain baseTx %d/%d: %s", i+1, dependentUTXOChainLength, err)
+			return
+		}
+		log.Printf("issued dependent X-chain baseTx %d/%d: %s (unconfirmed)", i+1, dependentUTXOChainLength, tx.ID())
+		chainTxs = append(chainTxs, tx)
+	}
+	log.Printf("issued %d dependent X-chain baseTxs in %s", dependentUTXOChainLength, time.Since(chainStartTime))
+
+	tailTx := chainTxs[len(chainTxs)-1]
+	w.confirmXChainTx(ctx, tailTx)
+
+	for i, tx := range chainTxs {
+		w.confirmXChainTx(ctx, tx)
+		w.verifyXChainTxConsumedUTXOs(ctx, tx)
+		log.Printf("confirmed dependent X-chain baseTx %d/%d: %s", i+1, dependentUTXOChainLength, tx.ID())
+	}
+}
@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"fmt"
 	"log"
 	"math/big"
 	"os"
@@ -15,16 +16,17 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/issuer"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
-	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/chain/x"
@@ -35,7 +37,13 @@ import (
 	ptxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
-const NumKeys = 5
+const (
+	NumKeys = 5
+
+	// numFlows is the number of distinct flows [workload.run] chooses
+	// between on each iteration.
+	numFlows = 10
+)
 
 func main() {
 	c, err := NewConfig(os.Args)
@@ -58,11 +66,21 @@ func main() {
 	}
 	log.Printf("synced wallet in %s", time.Since(walletSyncStartTime))
 
+	issr := issuer.New()
+	issr.Initialize(logging.NoLog{})
+	for _, uri := range c.URIs {
+		issr.RegisterChain(wallet.X().BlockchainID(), uri, issuer.NewXChainVM(uri))
+		issr.RegisterChain(constants.PlatformChainID, uri, issuer.NewPChainVM(uri))
+		issr.RegisterChain(wallet.C().BlockchainID(), uri, issuer.NewCChainVM(uri))
+	}
+
 	genesisWorkload := &workload{
 		id:     0,
 		wallet: wallet,
+		key:    genesis.EWOQKey,
 		addrs:  set.Of(genesis.EWOQKey.Address()),
 		uris:   c.URIs,
+		issuer: issr,
 	}
 
 	workloads := make([]*workload, NumKeys)
@@ -120,8 +138,10 @@ func main() {
 		workloads[i] = &workload{
 			id:     i,
 			wallet: wallet,
+			key:    key,
 			addrs:  set.Of(addr),
 			uris:   c.URIs,
+			issuer: issr,
 		}
 	}
 
@@ -167,8 +187,10 @@ func awaitHealthyNode(ctx context.Context, uri string) {
 type workload struct {
 	id     int
 	wallet primary.Wallet
+	key    *secp256k1.PrivateKey
 	addrs  set.Set[ids.ShortID]
 	uris   []string
+	issuer *issuer.Issuer
 }
 
 func (w *workload) run(ctx context.Context) {
@@ -199,7 +221,7 @@ func (w *workload) run(ctx context.Context) {
 	log.Printf("wallet starting with %d X-chain nAVAX and %d P-chain nAVAX", xAVAX, pAVAX)
 
 	for {
-		val, err := rand.Int(rand.Reader, big.NewInt(5))
+		val, err := rand.Int(rand.Reader, big.NewInt(numFlows))
 		if err != nil {
 			log.Fatalf("failed to read randomness: %s", err)
 		}
@@ -217,6 +239,16 @@ func (w *workload) run(ctx context.Context) {
 			w.issueXToPTransfer(ctx)
 		case 4:
 			w.issuePToXTransfer(ctx)
+		case 5:
+			w.issueXToCTransfer(ctx)
+		case 6:
+			w.issueCToPTransfer(ctx)
+		case 7:
+			w.issueCChainNativeTransfer(ctx)
+		case 8:
+			w.issueCreateSubnetFlow(ctx)
+		case 9:
+			w.issueXChainDependentChain(ctx)
 		}
 
 		val, err = rand.Int(rand.Reader, big.NewInt(int64(time.Second)))
@@ -532,40 +564,75 @@ func (w *workload) makeOwner() secp256k1fx.OutputOwners {
 	}
 }
 
-func (w *workload) confirmXChainTx(ctx context.Context, tx *xtxs.Tx) {
-	txID := tx.ID()
+// uriDecision pairs a URI with the status the transaction issued to it was
+// ultimately decided with.
+type uriDecision struct {
+	uri    string
+	status choices.Status
+}
+
+// awaitDecision submits txBytes for chainID to every URI in w.uris through
+// w.issuer, and blocks until each of them has independently decided it.
+// Submitting to every URI, rather than just the first, is what lets this
+// workload check that every node in the network reaches the same decision.
+// Routing the per-URI confirmations through the Issuer lets them, and this
+// workload's other in-flight transactions across chains and URIs, proceed
+// in parallel instead of one at a time. txBytes is expected to already have
+// been issued once by the wallet that built tx; resubmitting it here is a
+// no-op from each node's perspective, since it re-issues a transaction it
+// has already seen.
+func (w *workload) awaitDecision(ctx context.Context, chainID ids.ID, txBytes []byte) ([]uriDecision, error) {
+	decided := make(chan uriDecision, len(w.uris))
 	for _, uri := range w.uris {
-		client := avm.NewClient(uri, "X")
-		status, err := client.ConfirmTx(ctx, txID, 100*time.Millisecond)
-		if err != nil {
-			log.Printf("failed to confirm X-chain transaction %s on %s: %s", txID, uri, err)
-			return
+		uri := uri
+		onDecide := func(s choices.Status) { decided <- uriDecision{uri: uri, status: s} }
+		if err := w.issuer.IssueTx(ctx, chainID, uri, txBytes, onDecide); err != nil {
+			return nil, fmt.Errorf("failed to issue to %s: %w", uri, err)
 		}
-		if status != choices.Accepted {
-			log.Printf("failed to confirm X-chain transaction %s on %s: status == %s", txID, uri, status)
-			return
+	}
+
+	decisions := make([]uriDecision, 0, len(w.uris))
+	for range w.uris {
+		select {
+		case d := <-decided:
+			decisions = append(decisions, d)
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
-		log.Printf("confirmed X-chain transaction %s on %s", txID, uri)
 	}
-	log.Printf("confirmed X-chain transaction %s on all nodes", txID)
+	return decisions, nil
 }
 
-func (w *workload) confirmPChainTx(ctx context.Context, tx *ptxs.Tx) {
+func (w *workload) confirmXChainTx(ctx context.Context, tx *xtxs.Tx) {
 	txID := tx.ID()
-	for _, uri := range w.uris {
-		client := platformvm.NewClient(uri)
-		s, err := client.AwaitTxDecided(ctx, txID, 100*time.Millisecond)
-		if err != nil {
-			log.Printf("failed to confirm P-chain transaction %s on %s: %s", txID, uri, err)
+	decisions, err := w.awaitDecision(ctx, w.wallet.X().BlockchainID(), tx.Bytes())
+	if err != nil {
+		log.Printf("failed to confirm X-chain transaction %s via issuer: %s", txID, err)
+		return
+	}
+	for _, d := range decisions {
+		if d.status != choices.Accepted {
+			log.Printf("failed to confirm X-chain transaction %s on %s: status == %s", txID, d.uri, d.status)
 			return
 		}
-		if s.Status != status.Committed {
-			log.Printf("failed to confirm P-chain transaction %s on %s: status == %s", txID, uri, s.Status)
+	}
+	log.Printf("confirmed X-chain transaction %s on all nodes via issuer", txID)
+}
+
+func (w *workload) confirmPChainTx(ctx context.Context, tx *ptxs.Tx) {
+	txID := tx.ID()
+	decisions, err := w.awaitDecision(ctx, constants.PlatformChainID, tx.Bytes())
+	if err != nil {
+		log.Printf("failed to confirm P-chain transaction %s via issuer: %s", txID, err)
+		return
+	}
+	for _, d := range decisions {
+		if d.status != choices.Accepted {
+			log.Printf("failed to confirm P-chain transaction %s on %s: status == %s", txID, d.uri, d.status)
 			return
 		}
-		log.Printf("confirmed P-chain transaction %s on %s", txID, uri)
 	}
-	log.Printf("confirmed P-chain transaction %s on all nodes", txID)
+	log.Printf("confirmed P-chain transaction %s on all nodes via issuer", txID)
 }
 
 func (w *workload) verifyXChainTxConsumedUTXOs(ctx context.Context, tx *xtxs.Tx) {
@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errNoPrimaryNetworkValidators = errors.New("no primary network validators found")
+
+// customVMID identifies a VM that is not registered with any node in the
+// network. The chain created against it will never produce blocks, but the
+// CreateChainTx itself still needs to be accepted and show up in
+// GetBlockchains, which is all this flow exercises.
+var customVMID = ids.ID{'a', 'n', 't', 'i', 't', 'h', 'e', 's', 'i', 's', ' ', 'v', 'm'}
+
+// issueCreateSubnetFlow creates a new subnet, adds two chains to it (one
+// running the AVM, one running a custom, never-registered VM ID) and adds a
+// primary network validator as a validator of the subnet. It then confirms
+// that both chains are visible via GetBlockchains on every node.
+func (w *workload) issueCreateSubnetFlow(ctx context.Context) {
+	var (
+		pWallet = w.wallet.P()
+		owner   = w.makeOwner()
+	)
+
+	createSubnetStartTime := time.Now()
+	createSubnetTx, err := pWallet.IssueCreateSubnetTx(&owner)
+	if err != nil {
+		log.Printf("failed to issue CreateSubnetTx: %s", err)
+		return
+	}
+	log.Printf("created subnet %s in %s", createSubnetTx.ID(), time.Since(createSubnetStartTime))
+
+	w.confirmPChainTx(ctx, createSubnetTx)
+	w.verifyPChainTxConsumedUTXOs(ctx, createSubnetTx)
+
+	subnetID := createSubnetTx.ID()
+
+	avmGenesisBytes, err := buildAVMGenesisBytes(owner)
+	if err != nil {
+		log.Printf("failed to build AVM genesis for subnet %s: %s", subnetID, err)
+		return
+	}
+
+	createAVMChainStartTime := time.Now()
+	createAVMChainTx, err := pWallet.IssueCreateChainTx(
+		subnetID,
+		avmGenesisBytes,
+		constants.AVMID,
+		nil,
+		"antithesis-avm",
+	)
+	if err != nil {
+		log.Printf("failed to issue CreateChainTx for the AVM on subnet %s: %s", subnetID, err)
+		return
+	}
+	log.Printf("created AVM chain %s on subnet %s in %s", createAVMChainTx.ID(), subnetID, time.Since(createAVMChainStartTime))
+
+	w.confirmPChainTx(ctx, createAVMChainTx)
+	w.verifyPChainTxConsumedUTXOs(ctx, createAVMChainTx)
+
+	createCustomChainStartTime := time.Now()
+	createCustomChainTx, err := pWallet.IssueCreateChainTx(
+		subnetID,
+		[]byte("antithesis custom vm genesis"),
+		customVMID,
+		nil,
+		"antithesis-custom",
+	)
+	if err != nil {
+		log.Printf("failed to issue CreateChainTx for the custom VM on subnet %s: %s", subnetID, err)
+		return
+	}
+	log.Printf("created custom VM chain %s on subnet %s in %s", createCustomChainTx.ID(), subnetID, time.Since(createCustomChainStartTime))
+
+	w.confirmPChainTx(ctx, createCustomChainTx)
+	w.verifyPChainTxConsumedUTXOs(ctx, createCustomChainTx)
+
+	nodeID, err := w.pickPrimaryNetworkValidator(ctx)
+	if err != nil {
+		log.Printf("failed to pick a primary network validator to add to subnet %s: %s", subnetID, err)
+		return
+	}
+
+	addValidatorStartTime := time.Now()
+	addValidatorTx, err := pWallet.IssueAddSubnetValidatorTx(&txs.SubnetValidator{
+		Validator: txs.Validator{
+			NodeID: nodeID,
+			Start:  uint64(time.Now().Add(time.Minute).Unix()),
+			End:    uint64(time.Now().Add(21 * 24 * time.Hour).Unix()),
+			Wght:   20,
+		},
+		Subnet: subnetID,
+	})
+	if err != nil {
+		log.Printf("failed to issue AddSubnetValidatorTx for subnet %s: %s", subnetID, err)
+		return
+	}
+	log.Printf("added %s as a validator of subnet %s in %s", nodeID, subnetID, time.Since(addValidatorStartTime))
+
+	w.confirmPChainTx(ctx, addValidatorTx)
+	w.verifyPChainTxConsumedUTXOs(ctx, addValidatorTx)
+
+	w.verifyBlockchainsExist(ctx, createAVMChainTx.ID(), createCustomChainTx.ID())
+}
+
+// pickPrimaryNetworkValidator returns the node ID of an arbitrary current
+// primary network validator, so it can be registered as a subnet validator.
+func (w *workload) pickPrimaryNetworkValidator(ctx context.Context) (ids.NodeID, error) {
+	client := platformvm.NewClient(w.uris[0])
+	vdrs, err := client.GetCurrentValidators(ctx, constants.PrimaryNetworkID, nil)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	if len(vdrs) == 0 {
+		return ids.EmptyNodeID, errNoPrimaryNetworkValidators
+	}
+	return vdrs[0].NodeID, nil
+}
+
+// verifyBlockchainsExist confirms that every chainID in chainIDs is reported
+// by GetBlockchains on every URI.
+func (w *workload) verifyBlockchainsExist(ctx context.Context, chainIDs ...ids.ID) {
+	for _, uri := range w.uris {
+		client := platformvm.NewClient(uri)
+		blockchains, err := client.GetBlockchains(ctx)
+		if err != nil {
+			log.Printf("failed to fetch blockchains from %s: %s", uri, err)
+			return
+		}
+
+		seen := make(map[ids.ID]bool, len(blockchains))
+		for _, blockchain := range blockchains {
+			seen[blockchain.ID] = true
+		}
+
+		for _, chainID := range chainIDs {
+			if !seen[chainID] {
+				log.Printf("failed to find chain %s in GetBlockchains response from %s", chainID, uri)
+				return
+			}
+		}
+		log.Printf("confirmed chains %v are known to %s", chainIDs, uri)
+	}
+	log.Printf("confirmed chains %v are known to all nodes", chainIDs)
+}
+
+// buildAVMGenesisBytes constructs the genesis bytes for an AVM chain that
+// mints a single asset entirely owned by owner. This mirrors the genesis
+// construction performed by genesis.Genesis.Initialize for the X-chain.
+func buildAVMGenesisBytes(owner secp256k1fx.OutputOwners) ([]byte, error) {
+	genesis := &avm.Genesis{
+		Txs: []*avm.GenesisAsset{
+			{
+				Asset: avm.Asset{
+					Name:   "antithesis subnet asset",
+					Symbol: "ANTI",
+				},
+				InitialState: map[string][]interface{}{
+					"fixedCap": {
+						&secp256k1fx.TransferOutput{
+							Amt:          1,
+							OutputOwners: owner,
+						},
+					},
+				},
+			},
+		},
+	}
+	return avm.Parser.Codec().Marshal(avm.CodecVersion, genesis)
+}